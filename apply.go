@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/umegbewe/mason/providers"
+)
+
+// applyPlan executes a previously computed plan: creates and updates
+// secrets, skipping no-ops. This is the direct successor of mason's
+// original single-shot manageSecrets.
+func applyPlan(config Config, actions []Action, kms string, resolveProvider providerResolver) {
+	for _, a := range actions {
+		if a.Error != "" {
+			log.Printf("Skipping secret %s: %s", a.Name, a.Error)
+			continue
+		}
+
+		secret := config.Secrets[a.Name]
+
+		provider, err := resolveProvider(secret.Type)
+		if err != nil {
+			log.Printf("Failed to resolve provider for secret %s: %v", a.Name, err)
+			continue
+		}
+
+		switch a.Kind {
+		case ActionNoOp:
+			fmt.Printf("Secret %s has no changes, skipping update\n", a.Name)
+
+		case ActionUpdate:
+			if err := provider.Update(a.Name, a.DesiredValue, kms); err != nil {
+				log.Printf("Failed to update secret %s: %v", a.Name, err)
+				continue
+			}
+			fmt.Printf("Secret %s updated successfully\n", a.Name)
+
+		case ActionCreate:
+			if err := provider.Create(a.Name, a.DesiredValue, secret.Tags, kms); err != nil {
+				log.Printf("Failed to create secret %s: %v", a.Name, err)
+				continue
+			}
+			fmt.Printf("Secret %s created successfully\n", a.Name)
+		}
+
+		reconcileLifecycle(provider, a.Name, secret)
+	}
+}
+
+// reconcileLifecycle applies a secret's rotation policy and replica region
+// list on every apply, independent of whether the value itself changed, so
+// drift in those settings (e.g. someone hand-edited replication in the
+// console) is corrected too.
+func reconcileLifecycle(provider providers.Provider, name string, secret SecretConfig) {
+	if secret.Rotation != nil {
+		rotator, ok := provider.(providers.Rotator)
+		if !ok {
+			log.Printf("Secret %s declares a rotation policy but provider does not support rotation", name)
+		} else {
+			policy := providers.RotationPolicy{
+				LambdaARN:              secret.Rotation.LambdaARN,
+				AutomaticallyAfterDays: secret.Rotation.AutomaticallyAfterDays,
+				Duration:               secret.Rotation.Duration,
+				ScheduleExpression:     secret.Rotation.ScheduleExpression,
+			}
+			if err := rotator.ConfigureRotation(name, policy, secret.VersionStages); err != nil {
+				log.Printf("Failed to configure rotation for secret %s: %v", name, err)
+			}
+		}
+	}
+
+	if len(secret.ReplicaRegions) > 0 {
+		replicator, ok := provider.(providers.ReplicationReconciler)
+		if !ok {
+			log.Printf("Secret %s declares replica_regions but provider does not support replication", name)
+		} else if err := replicator.ReconcileReplication(name, secret.ReplicaRegions); err != nil {
+			log.Printf("Failed to reconcile replication for secret %s: %v", name, err)
+		}
+	}
+}
+
+// pruneManaged deletes secrets the backend says are tagged as managed by
+// mason but that no longer appear in config, implementing the drift/
+// reconciliation loop analogous to `terraform apply` removing resources
+// dropped from a plan.
+func pruneManaged(config Config, resolveProvider providerResolver, tagKey, tagValue string, recoveryWindowDays int64) error {
+	provider, err := resolveProvider("")
+	if err != nil {
+		return fmt.Errorf("resolving provider: %w", err)
+	}
+
+	pruner, ok := provider.(providers.Pruner)
+	if !ok {
+		return fmt.Errorf("provider does not support -prune (no ListManaged/DeleteWithRecovery)")
+	}
+
+	managed, err := pruner.ListManaged(tagKey, tagValue)
+	if err != nil {
+		return fmt.Errorf("listing managed secrets: %w", err)
+	}
+
+	for _, name := range managed {
+		if _, declared := config.Secrets[name]; declared {
+			continue
+		}
+
+		if err := pruner.DeleteWithRecovery(name, recoveryWindowDays); err != nil {
+			log.Printf("Failed to prune secret %s: %v", name, err)
+			continue
+		}
+
+		fmt.Printf("Secret %s pruned (not present in config)\n", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func cmdApply(args []string) {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	profile := fs.String("profile", "default", "AWS profile to use")
+	configPath := fs.String("config", "", "Path to the config file")
+	region := fs.String("region", "us-east-1", "AWS region")
+	kms := fs.String("kms", "", "KMS key ID or alias to use for encrypting the secrets")
+	autoApprove := fs.Bool("auto-approve", false, "Apply without prompting for confirmation")
+	prune := fs.Bool("prune", false, "Delete secrets tagged managed-by=mason that are no longer in config")
+	tagKey := fs.String("prune-tag-key", "managed-by", "Tag key used to identify mason-managed secrets when pruning")
+	tagValue := fs.String("prune-tag-value", "mason", "Tag value used to identify mason-managed secrets when pruning")
+	recoveryWindowDays := fs.Int64("recovery-window-days", 30, "Recovery window (in days) for pruned secrets; 0 deletes immediately where the backend supports it")
+	fs.Parse(args)
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	resolveProvider := newProviderResolver(config, *profile, *region)
+	actions := planSecrets(config, resolveProvider)
+
+	printPlanTable(actions)
+
+	if !*autoApprove && !confirm() {
+		fmt.Println("Apply cancelled")
+		return
+	}
+
+	applyPlan(config, actions, *kms, resolveProvider)
+
+	if *prune {
+		if err := pruneManaged(config, resolveProvider, *tagKey, *tagValue, *recoveryWindowDays); err != nil {
+			log.Fatalf("Prune failed: %v", err)
+		}
+	}
+}
+
+func confirm() bool {
+	fmt.Print("\nApply these changes? [y/N] ")
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	return strings.EqualFold(strings.TrimSpace(answer), "y")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"log"
+)
+
+func cmdDestroy(args []string) {
+	fs := flag.NewFlagSet("destroy", flag.ExitOnError)
+	profile := fs.String("profile", "default", "AWS profile to use")
+	configPath := fs.String("config", "", "Path to the config file (used to resolve per-secret provider types)")
+	region := fs.String("region", "us-east-1", "AWS region")
+	removePath := fs.String("remove", "", "Path to a YAML file listing secret names to remove")
+	fs.Parse(args)
+
+	var config Config
+	if *configPath != "" {
+		c, err := loadConfig(*configPath)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		config = c
+	}
+
+	if *removePath == "" {
+		log.Fatalf("destroy requires -remove")
+	}
+
+	names, err := loadRemoveList(*removePath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	destroySecrets(config, names, newProviderResolver(config, *profile, *region))
+}
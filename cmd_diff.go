@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func cmdDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	profile := fs.String("profile", "default", "AWS profile to use")
+	configPath := fs.String("config", "", "Path to the config file")
+	region := fs.String("region", "us-east-1", "AWS region")
+	jsonOut := fs.Bool("json", false, "Print the diff as JSON instead of text")
+	fs.Parse(args)
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	diffs, err := diffKeyValueSecrets(config, newProviderResolver(config, *profile, *region))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diffs); err != nil {
+			log.Fatalf("Failed to encode diff: %v", err)
+		}
+		return
+	}
+
+	for _, d := range diffs {
+		if d.Error != "" {
+			fmt.Printf("%s: error: %s\n", d.Name, d.Error)
+			continue
+		}
+
+		if !d.HasChanges() {
+			fmt.Printf("%s: no changes\n", d.Name)
+			continue
+		}
+
+		fmt.Printf("%s:\n", d.Name)
+		for k, v := range d.Added {
+			fmt.Printf("  + %s = %s\n", k, v)
+		}
+		for k, v := range d.Changed {
+			fmt.Printf("  ~ %s = %s\n", k, v)
+		}
+		for k, v := range d.Removed {
+			fmt.Printf("  - %s = %s\n", k, v)
+		}
+	}
+}
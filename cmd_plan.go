@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func cmdPlan(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	profile := fs.String("profile", "default", "AWS profile to use")
+	configPath := fs.String("config", "", "Path to the config file")
+	region := fs.String("region", "us-east-1", "AWS region")
+	jsonOut := fs.Bool("json", false, "Print the plan as JSON instead of a table")
+	fs.Parse(args)
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	actions := planSecrets(config, newProviderResolver(config, *profile, *region))
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(actions); err != nil {
+			log.Fatalf("Failed to encode plan: %v", err)
+		}
+		return
+	}
+
+	printPlanTable(actions)
+	fmt.Printf("\n%d secret(s) planned\n", len(actions))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// reconcileKeyValue computes the final JSON value to store for a key_value
+// secret and whether it actually differs from what's already in the
+// backend.
+//
+// Comparison is structural (map equality), not byte-for-byte: a secret
+// authored by another tool may hold the same keys and values with a
+// different key order or whitespace than json.Marshal (which sorts map
+// keys) produces, and a raw string comparison would report spurious drift
+// against it. When secret.Merge is set, keys already present in the
+// remote secret that mason doesn't declare are preserved, so multiple teams
+// can share one JSON secret and each only manage their own keys.
+func reconcileKeyValue(secret SecretConfig, currentRaw string) (final string, equal bool, err error) {
+	var currentMap map[string]interface{}
+	if currentRaw != "" {
+		if err := json.Unmarshal([]byte(currentRaw), &currentMap); err != nil {
+			// Not a JSON object: there's nothing to merge with, so treat
+			// it the same as "doesn't exist yet" for comparison purposes.
+			currentMap = nil
+		}
+	}
+
+	desiredMap := make(map[string]interface{}, len(secret.KeyValue))
+	for k, v := range secret.KeyValue {
+		desiredMap[k] = v
+	}
+
+	finalMap := desiredMap
+	if secret.Merge {
+		finalMap = make(map[string]interface{}, len(currentMap)+len(desiredMap))
+		for k, v := range currentMap {
+			finalMap[k] = v
+		}
+		for k, v := range desiredMap {
+			finalMap[k] = v
+		}
+	}
+
+	finalBytes, err := json.Marshal(finalMap)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(finalBytes), reflect.DeepEqual(currentMap, finalMap), nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestReconcileKeyValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     SecretConfig
+		currentRaw string
+		wantFinal  string
+		wantEqual  bool
+		wantErr    bool
+	}{
+		{
+			name:       "no current value",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}},
+			currentRaw: "",
+			wantFinal:  `{"a":"1"}`,
+			wantEqual:  false,
+		},
+		{
+			name:       "equal to current",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}},
+			currentRaw: `{"a":"1"}`,
+			wantFinal:  `{"a":"1"}`,
+			wantEqual:  true,
+		},
+		{
+			name:       "merge preserves undeclared remote keys",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}, Merge: true},
+			currentRaw: `{"a":"0","b":"2"}`,
+			wantFinal:  `{"a":"1","b":"2"}`,
+			wantEqual:  false,
+		},
+		{
+			name:       "without merge, undeclared remote keys are dropped",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}},
+			currentRaw: `{"a":"1","b":"2"}`,
+			wantFinal:  `{"a":"1"}`,
+			wantEqual:  false,
+		},
+		{
+			name:       "non-JSON current value is treated as not existing",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}},
+			currentRaw: "not-json",
+			wantFinal:  `{"a":"1"}`,
+			wantEqual:  false,
+		},
+		{
+			name:       "non-JSON current value with merge is treated as empty",
+			secret:     SecretConfig{KeyValue: map[string]string{"a": "1"}, Merge: true},
+			currentRaw: "not-json",
+			wantFinal:  `{"a":"1"}`,
+			wantEqual:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, equal, err := reconcileKeyValue(tt.secret, tt.currentRaw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reconcileKeyValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if final != tt.wantFinal {
+				t.Errorf("final = %s, want %s", final, tt.wantFinal)
+			}
+			if equal != tt.wantEqual {
+				t.Errorf("equal = %v, want %v", equal, tt.wantEqual)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"gopkg.in/yaml.v2"
+)
+
+// removeList is the YAML shape of a -remove file: a flat list of secret
+// names to delete.
+type removeList struct {
+	Remove []string `yaml:"remove"`
+}
+
+func loadRemoveList(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading remove file: %w", err)
+	}
+
+	var list removeList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing remove file: %w", err)
+	}
+
+	return list.Remove, nil
+}
+
+// destroySecrets deletes each named secret through its configured provider.
+// Secrets not present in config fall back to the default provider type.
+func destroySecrets(config Config, names []string, resolveProvider providerResolver) {
+	for _, name := range names {
+		kind := ""
+		if secret, ok := config.Secrets[name]; ok {
+			kind = secret.Type
+		}
+
+		provider, err := resolveProvider(kind)
+		if err != nil {
+			log.Printf("Failed to resolve provider for secret %s: %v", name, err)
+			continue
+		}
+
+		if err := provider.Delete(name); err != nil {
+			log.Printf("Failed to delete secret %s: %v", name, err)
+			continue
+		}
+
+		fmt.Printf("Secret %s destroyed\n", name)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/umegbewe/mason/providers"
+)
+
+// KeyValueDiff describes how a key_value secret's remote value differs from
+// what config declares.
+type KeyValueDiff struct {
+	Name    string            `json:"name"`
+	Added   map[string]string `json:"added,omitempty"`
+	Removed map[string]string `json:"removed,omitempty"`
+	Changed map[string]string `json:"changed,omitempty"` // new value only; the old value is not recorded
+	Error   string            `json:"error,omitempty"`
+}
+
+// HasChanges reports whether the diff carries any actual difference.
+func (d KeyValueDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// diffKeyValueSecrets computes a structured diff between the remote and
+// declared maps of every key_value secret in config. Secrets that are not
+// key_value (plaintext/file/source) are skipped since there's nothing
+// structured to diff.
+func diffKeyValueSecrets(config Config, resolveProvider providerResolver) ([]KeyValueDiff, error) {
+	var diffs []KeyValueDiff
+
+	for name, secret := range config.Secrets {
+		if secret.KeyValue == nil {
+			continue
+		}
+
+		provider, err := resolveProvider(secret.Type)
+		if err != nil {
+			return nil, fmt.Errorf("resolving provider for secret %s: %w", name, err)
+		}
+
+		current, err := provider.Get(name)
+		if err == providers.ErrNotFound {
+			diffs = append(diffs, KeyValueDiff{Name: name, Added: secret.KeyValue})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("describing secret %s: %w", name, err)
+		}
+
+		var remote map[string]string
+		if err := json.Unmarshal([]byte(current), &remote); err != nil {
+			// Not a JSON object, e.g. authored by another tool: there's
+			// nothing structured to compare against, so report it on this
+			// secret alone rather than aborting the whole diff run.
+			diffs = append(diffs, KeyValueDiff{Name: name, Error: fmt.Sprintf("not a key_value JSON object: %v", err)})
+			continue
+		}
+
+		diffs = append(diffs, diffMaps(name, remote, secret.KeyValue))
+	}
+
+	return diffs, nil
+}
+
+func diffMaps(name string, remote, desired map[string]string) KeyValueDiff {
+	d := KeyValueDiff{Name: name}
+
+	for k, v := range desired {
+		remoteValue, ok := remote[k]
+		if !ok {
+			if d.Added == nil {
+				d.Added = map[string]string{}
+			}
+			d.Added[k] = v
+			continue
+		}
+		if remoteValue != v {
+			if d.Changed == nil {
+				d.Changed = map[string]string{}
+			}
+			d.Changed[k] = v
+		}
+	}
+
+	for k, v := range remote {
+		if _, ok := desired[k]; !ok {
+			if d.Removed == nil {
+				d.Removed = map[string]string{}
+			}
+			d.Removed[k] = v
+		}
+	}
+
+	return d
+}
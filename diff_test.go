@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		remote  map[string]string
+		desired map[string]string
+		want    KeyValueDiff
+	}{
+		{
+			name:    "no changes",
+			remote:  map[string]string{"a": "1"},
+			desired: map[string]string{"a": "1"},
+			want:    KeyValueDiff{Name: "s"},
+		},
+		{
+			name:    "added key",
+			remote:  map[string]string{"a": "1"},
+			desired: map[string]string{"a": "1", "b": "2"},
+			want:    KeyValueDiff{Name: "s", Added: map[string]string{"b": "2"}},
+		},
+		{
+			name:    "removed key",
+			remote:  map[string]string{"a": "1", "b": "2"},
+			desired: map[string]string{"a": "1"},
+			want:    KeyValueDiff{Name: "s", Removed: map[string]string{"b": "2"}},
+		},
+		{
+			name:    "changed key",
+			remote:  map[string]string{"a": "1"},
+			desired: map[string]string{"a": "2"},
+			want:    KeyValueDiff{Name: "s", Changed: map[string]string{"a": "2"}},
+		},
+		{
+			name:    "added, removed, and changed together",
+			remote:  map[string]string{"a": "1", "b": "2"},
+			desired: map[string]string{"a": "9", "c": "3"},
+			want: KeyValueDiff{
+				Name:    "s",
+				Added:   map[string]string{"c": "3"},
+				Removed: map[string]string{"b": "2"},
+				Changed: map[string]string{"a": "9"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffMaps("s", tt.remote, tt.desired)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffMaps() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyValueDiffHasChanges(t *testing.T) {
+	if (KeyValueDiff{Name: "s"}).HasChanges() {
+		t.Error("empty diff should report no changes")
+	}
+	if !(KeyValueDiff{Name: "s", Added: map[string]string{"a": "1"}}).HasChanges() {
+		t.Error("diff with an added key should report changes")
+	}
+}
@@ -1,88 +1,136 @@
+// Command mason syncs secrets declared in a YAML config into one or more
+// secret backends. It is organized as subcommands: `plan` previews the
+// changes, `apply` executes them, `diff` inspects drift in key_value
+// secrets, and `destroy` removes secrets explicitly.
 package main
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"os"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"gopkg.in/yaml.v2"
+
+	"github.com/umegbewe/mason/providers"
+	"github.com/umegbewe/mason/sources"
 )
 
+// defaultProviderType preserves mason's original behavior of talking to AWS
+// Secrets Manager when a config doesn't opt into another backend.
+const defaultProviderType = "aws-secretsmanager"
+
 type Config struct {
-	Secrets map[string]struct {
-		KeyValue  map[string]string `yaml:"key_value,omitempty"`
-		PlainText string            `yaml:"plaintext,omitempty"`
-		File      string            `yaml:"file,omitempty"`
-		Tags      map[string]string `yaml:"tags"`
-	} `yaml:"secrets"`
+	Type    string                  `yaml:"type,omitempty"`
+	Secrets map[string]SecretConfig `yaml:"secrets"`
 }
 
-type CLIOpts struct {
-	Profile string
-	Config  string
-	Region  string
-	KMSKey  string
+// SecretConfig is the YAML shape of one entry under `secrets:`.
+type SecretConfig struct {
+	Type           string                 `yaml:"type,omitempty"`
+	KeyValue       map[string]string      `yaml:"key_value,omitempty"`
+	PlainText      string                 `yaml:"plaintext,omitempty"`
+	File           string                 `yaml:"file,omitempty"`
+	Source         map[string]interface{} `yaml:"source,omitempty"`
+	Tags           map[string]string      `yaml:"tags"`
+	Merge          bool                   `yaml:"merge,omitempty"`
+	Rotation       *RotationConfig        `yaml:"rotation,omitempty"`
+	VersionStages  []string               `yaml:"version_stages,omitempty"`
+	VersionStage   string                 `yaml:"version_stage,omitempty"`
+	ReplicaRegions []string               `yaml:"replica_regions,omitempty"`
 }
 
-func main() {
-
-	cli := parseFlags()
+// RotationConfig is the YAML shape of a secret's `rotation:` block.
+type RotationConfig struct {
+	LambdaARN              string `yaml:"lambda_arn"`
+	AutomaticallyAfterDays int64  `yaml:"automatically_after_days,omitempty"`
+	Duration               string `yaml:"duration,omitempty"`
+	ScheduleExpression     string `yaml:"schedule_expression,omitempty"`
+}
 
-	data, err := ioutil.ReadFile(cli.Config)
-	if err != nil {
-		log.Fatalf("Failed to read config: %v", err)
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
 	}
 
-	var config Config
-
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		log.Printf("Failed to parse config: %v", err)
+	switch os.Args[1] {
+	case "plan":
+		cmdPlan(os.Args[2:])
+	case "apply":
+		cmdApply(os.Args[2:])
+	case "diff":
+		cmdDiff(os.Args[2:])
+	case "destroy":
+		cmdDestroy(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(1)
 	}
+}
 
-	err = validateConfig(config)
-	if err != nil {
-		log.Fatalf("Invalid config: %v", err)
-	}
+func usage() {
+	fmt.Fprintln(os.Stderr, `mason is a secrets sync tool.
 
-	sess, err := createAWSSession(cli.Profile, cli.Region)
+Usage:
 
-	svc := secretsmanager.New(sess)
+  mason <command> [flags]
 
-	manageSecrets(svc, config, &cli.KMSKey)
+Commands:
 
+  plan     print the create/update/no-op set without changing anything
+           (secrets that apply -prune would delete are not listed)
+  apply    execute the plan (the former single-shot mason behavior)
+  diff     show a structured diff of key_value secrets against the backend
+  destroy  remove secrets named in a -remove file`)
 }
 
-func parseFlags() CLIOpts {
-	profile := flag.String("profile", "default", "AWS profile to use")
-	configPath := flag.String("config", "", "Path to the config file")
-	region := flag.String("region", "us-east-1", "AWS region")
-	kms := flag.String("kms", "", "KMS key ID or alias to use for encrypting the secrets")
+func loadConfig(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading config: %w", err)
+	}
 
-	flag.Parse()
+	if isEncryptedConfigFile(path, data) {
+		data, err = decryptConfig(data)
+		if err != nil {
+			return Config{}, fmt.Errorf("decrypting config: %w", err)
+		}
+	}
 
-	return CLIOpts{
-		Profile: *profile,
-		Config:  *configPath,
-		Region:  *region,
-		KMSKey:  *kms,
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("parsing config: %w", err)
 	}
+
+	if err := decryptInlineValues(&config); err != nil {
+		return Config{}, fmt.Errorf("decrypting inline secrets: %w", err)
+	}
+
+	if err := validateConfig(config); err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return config, nil
 }
 
 func validateConfig(config Config) error {
 	for name, secret := range config.Secrets {
-		if secret.KeyValue != nil && secret.File != "" {
-			return fmt.Errorf("secret '%s' has both KeyValue and File set, which is not allowed", name)
+		set := 0
+		for _, has := range []bool{secret.KeyValue != nil, secret.File != "", secret.PlainText != "", secret.Source != nil} {
+			if has {
+				set++
+			}
 		}
 
-		if secret.KeyValue == nil && secret.File == "" && secret.PlainText == "" {
-			return fmt.Errorf("secret '%s' must have either KeyValue, File, or PlainText set", name)
+		if set == 0 {
+			return fmt.Errorf("secret '%s' must have exactly one of KeyValue, File, PlainText, or Source set", name)
+		}
+		if set > 1 {
+			return fmt.Errorf("secret '%s' has more than one of KeyValue, File, PlainText, or Source set, which is ambiguous", name)
 		}
 
 		for tagKey, tagValue := range secret.Tags {
@@ -94,106 +142,87 @@ func validateConfig(config Config) error {
 	return nil
 }
 
-func createAWSSession(profile, region string) (*session.Session, error) {
-	sessOpts := session.Options{
-		Profile: profile,
-		Config: aws.Config{
-			Region: aws.String(region),
-		},
-	}
-
-	return session.NewSessionWithOptions(sessOpts)
-}
+// providerResolver returns the Provider that should handle a secret with the
+// given `type:` override, falling back to the config-level or default
+// backend when kind is empty.
+type providerResolver func(kind string) (providers.Provider, error)
 
-func manageSecrets(svc *secretsmanager.SecretsManager, config Config, kms *string) {
-	for name, secret := range config.Secrets {
-		var secretValue string
+// newProviderResolver builds a providerResolver that lazily constructs and
+// caches one Provider per backend type, so secrets sharing a `type:` reuse
+// the same session/client.
+func newProviderResolver(config Config, profile, region string) providerResolver {
+	cache := map[string]providers.Provider{}
 
-		if secret.KeyValue != nil {
-			marshaledValue, err := json.Marshal(secret.KeyValue)
-			if err != nil {
-				log.Printf("Failed to marshal secret %s: %v", name, err)
-				continue
-			}
-			secretValue = string(marshaledValue)
-		} else if secret.File != "" {
-			content, err := ioutil.ReadFile(secret.File)
-			if err != nil {
-				log.Printf("Failed to read file %s: %v", secret.File, err)
-				continue
-			}
-			secretValue = string(content)
-		} else {
-			secretValue = secret.PlainText
+	return func(kind string) (providers.Provider, error) {
+		if kind == "" {
+			kind = config.Type
+		}
+		if kind == "" {
+			kind = defaultProviderType
 		}
 
-		tags := make([]*secretsmanager.Tag, 0, len(secret.Tags))
-		for k, v := range secret.Tags {
-			tags = append(tags, &secretsmanager.Tag{
-				Key:   aws.String(k),
-				Value: aws.String(v),
-			})
+		if p, ok := cache[kind]; ok {
+			return p, nil
 		}
 
-		// this is to avoid updating the secret if the value is the same
-		currentValue, err := svc.GetSecretValue(&secretsmanager.GetSecretValueInput{
-			SecretId: aws.String(name),
+		p, err := providers.New(kind, providers.Config{
+			Type:    kind,
+			Region:  region,
+			Profile: profile,
 		})
+		if err != nil {
+			return nil, err
+		}
 
-		switch {
-		case err == nil:
-			// If current value is the same as new value, skip update
-			if currentValue.SecretString != nil && *currentValue.SecretString == string(secretValue) {
-				fmt.Printf("Secret %s has no changes, skipping update\n", name)
-				continue
-			}
+		cache[kind] = p
+		return p, nil
+	}
+}
 
-			updateInput := &secretsmanager.UpdateSecretInput{
-				SecretId:     aws.String(name),
-				SecretString: aws.String(string(secretValue)),
-				KmsKeyId:     kms,
-			}
+// resolveSecretValue computes the desired value for a secret, whether it
+// comes from a static plaintext/file/key_value field or a dynamic source.
+// refs holds other secrets' already-resolved values, made available to
+// sources (such as template) that can reference them.
+func resolveSecretValue(secret SecretConfig, refs map[string]string) (string, error) {
+	if secret.Source != nil {
+		return resolveSource(secret.Source, refs)
+	}
 
-			if *kms != "" {
-				updateInput.KmsKeyId = kms
-			}
+	if secret.KeyValue != nil {
+		marshaled, err := json.Marshal(secret.KeyValue)
+		if err != nil {
+			return "", fmt.Errorf("marshaling key_value: %w", err)
+		}
+		return string(marshaled), nil
+	}
 
-			_, err = svc.UpdateSecret(updateInput)
-			if err != nil {
-				log.Printf("Failed to update secret %s: %v", name, err)
-			} else {
-				fmt.Printf("Secret %s updated successfully\n", name)
-			}
+	if secret.File != "" {
+		content, err := ioutil.ReadFile(secret.File)
+		if err != nil {
+			return "", fmt.Errorf("reading file %s: %w", secret.File, err)
+		}
+		return string(content), nil
+	}
 
-		case isAWSError(err, secretsmanager.ErrCodeResourceNotFoundException):
-			createInput := &secretsmanager.CreateSecretInput{
-				Name:         aws.String(name),
-				SecretString: aws.String(string(secretValue)),
-				KmsKeyId:     kms,
-				Tags:         tags,
-			}
+	return secret.PlainText, nil
+}
 
-			if *kms != "" {
-				createInput.KmsKeyId = kms
-			}
+// resolveSource builds the Source named by a `source:` block's `kind` field
+// and resolves its value.
+func resolveSource(raw map[string]interface{}, refs map[string]string) (string, error) {
+	kind, _ := raw["kind"].(string)
+	if kind == "" {
+		return "", fmt.Errorf("source block is missing a kind")
+	}
 
-			_, err := svc.CreateSecret(createInput)
-			if err != nil {
-				log.Printf("Failed to create secret %s: %v", name, err)
-			} else {
-				fmt.Printf("Secret %s created successfully\n", name)
-			}
-		default:
-			log.Printf("Failed to describe secret %s: %v", name, err)
-		}
+	src, err := sources.New(kind, raw)
+	if err != nil {
+		return "", err
 	}
-}
 
-func isAWSError(err error, code string) bool {
-	if aerr, ok := err.(awserr.Error); ok {
-		if aerr.Code() == code {
-			return true
-		}
+	if setter, ok := src.(sources.RefsSetter); ok {
+		setter.SetRefs(refs)
 	}
-	return false
+
+	return src.Resolve()
 }
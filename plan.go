@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/umegbewe/mason/providers"
+)
+
+// ActionKind describes what an apply would do for one secret.
+type ActionKind string
+
+const (
+	ActionCreate ActionKind = "create"
+	ActionUpdate ActionKind = "update"
+	ActionNoOp   ActionKind = "no-op"
+	ActionDelete ActionKind = "delete"
+)
+
+// Action is one line of a mason plan: what would happen to a single named
+// secret, and the value it would be synced to.
+type Action struct {
+	Name         string     `json:"name"`
+	Kind         ActionKind `json:"action"`
+	CurrentValue string     `json:"current_value,omitempty"`
+	DesiredValue string     `json:"desired_value,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// planSecrets computes the create/update/no-op set for every secret in
+// config without mutating anything in the backend.
+func planSecrets(config Config, resolveProvider providerResolver) []Action {
+	actions := make([]Action, 0, len(config.Secrets))
+	resolved := resolveAllSourcedSecrets(config)
+
+	for name, secret := range config.Secrets {
+		provider, err := resolveProvider(secret.Type)
+		if err != nil {
+			actions = append(actions, Action{Name: name, Kind: ActionNoOp, Error: err.Error()})
+			continue
+		}
+
+		current, getErr := getCurrentValue(provider, name, secret.VersionStage)
+		if getErr != nil && getErr != providers.ErrNotFound {
+			actions = append(actions, Action{Name: name, Kind: ActionNoOp, Error: fmt.Sprintf("describing secret: %v", getErr)})
+			continue
+		}
+		if getErr == providers.ErrNotFound {
+			current = ""
+		}
+
+		desired, equal, err := planSecretValue(name, secret, current, resolved)
+		if err != nil {
+			actions = append(actions, Action{Name: name, Kind: ActionNoOp, Error: err.Error()})
+			continue
+		}
+
+		switch {
+		case getErr == providers.ErrNotFound:
+			actions = append(actions, Action{Name: name, Kind: ActionCreate, DesiredValue: desired})
+
+		case equal:
+			actions = append(actions, Action{Name: name, Kind: ActionNoOp, CurrentValue: current, DesiredValue: desired})
+
+		default:
+			actions = append(actions, Action{Name: name, Kind: ActionUpdate, CurrentValue: current, DesiredValue: desired})
+		}
+	}
+
+	return actions
+}
+
+// planSecretValue resolves a secret's desired value and whether it matches
+// what's already stored. key_value secrets are compared structurally (and
+// merged, if requested); plaintext/file/source secrets use the value
+// already computed by resolveAllSourcedSecrets, so a source is never
+// resolved more than once per plan/apply (important for exec and http
+// sources, which can have side effects).
+func planSecretValue(name string, secret SecretConfig, current string, resolved map[string]secretResolution) (desired string, equal bool, err error) {
+	if secret.KeyValue != nil {
+		return reconcileKeyValue(secret, current)
+	}
+
+	r := resolved[name]
+	if r.err != nil {
+		return "", false, r.err
+	}
+
+	return r.value, current == r.value, nil
+}
+
+// secretResolution is a source/plaintext/file secret's resolved value or
+// the error that resolving it produced.
+type secretResolution struct {
+	value string
+	err   error
+}
+
+// resolveAllSourcedSecrets resolves every non-key_value secret's value
+// exactly once, so the result can be reused both as other secrets' template
+// refs and as the desired value planSecretValue compares against the
+// backend. key_value secrets are also resolved into refs (so templates can
+// still reference them, as a JSON-marshaled string) but, since
+// planSecretValue reconciles their desired value separately through
+// reconcileKeyValue, their resolution isn't kept in the returned map.
+// Non-template secrets resolve first so their values are available to
+// templates via refs; template secrets resolve afterward using that ref
+// map.
+func resolveAllSourcedSecrets(config Config) map[string]secretResolution {
+	resolved := make(map[string]secretResolution, len(config.Secrets))
+	refs := make(map[string]string, len(config.Secrets))
+
+	for name, secret := range config.Secrets {
+		if isTemplateSource(secret) {
+			continue
+		}
+
+		value, err := resolveSecretValue(secret, nil)
+		if err == nil {
+			refs[name] = value
+		}
+		if secret.KeyValue == nil {
+			resolved[name] = secretResolution{value: value, err: err}
+		}
+	}
+
+	for name, secret := range config.Secrets {
+		if secret.KeyValue != nil || !isTemplateSource(secret) {
+			continue
+		}
+
+		value, err := resolveSecretValue(secret, refs)
+		resolved[name] = secretResolution{value: value, err: err}
+	}
+
+	return resolved
+}
+
+// isTemplateSource reports whether secret's source (if any) is a template,
+// which needs other secrets' refs and so must resolve after them.
+func isTemplateSource(secret SecretConfig) bool {
+	if secret.Source == nil {
+		return false
+	}
+	kind, _ := secret.Source["kind"].(string)
+	return kind == "template"
+}
+
+// getCurrentValue reads a secret's value, pinning a specific version
+// staging label (AWSCURRENT, AWSPREVIOUS, a custom stage) when the config
+// requests one and the backend supports it.
+func getCurrentValue(provider providers.Provider, name, versionStage string) (string, error) {
+	if versionStage == "" {
+		return provider.Get(name)
+	}
+
+	versioned, ok := provider.(providers.VersionedGetter)
+	if !ok {
+		return "", fmt.Errorf("provider does not support pinning version_stage %q", versionStage)
+	}
+
+	return versioned.GetVersion(name, versionStage)
+}
+
+// printPlanTable renders actions as a simple aligned table, mirroring
+// mason's existing plain Printf-based console output.
+func printPlanTable(actions []Action) {
+	for _, a := range actions {
+		if a.Error != "" {
+			fmt.Printf("%-8s %-40s error: %s\n", a.Kind, a.Name, a.Error)
+			continue
+		}
+		fmt.Printf("%-8s %-40s\n", a.Kind, a.Name)
+	}
+}
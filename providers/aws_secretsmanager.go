@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+func init() {
+	Register("aws-secretsmanager", newSecretsManagerProvider)
+}
+
+// secretsManagerProvider is the default provider and preserves mason's
+// original AWS Secrets Manager behavior.
+type secretsManagerProvider struct {
+	svc *secretsmanager.SecretsManager
+}
+
+func newSecretsManagerProvider(cfg Config) (Provider, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Profile: cfg.Profile,
+		Config: aws.Config{
+			Region: aws.String(cfg.Region),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretsManagerProvider{svc: secretsmanager.New(sess)}, nil
+}
+
+func (p *secretsManagerProvider) Get(name string) (string, error) {
+	out, err := p.svc.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	})
+	if err != nil {
+		if isAWSError(err, secretsmanager.ErrCodeResourceNotFoundException) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	if out.SecretString == nil {
+		return "", ErrNotFound
+	}
+
+	return *out.SecretString, nil
+}
+
+func (p *secretsManagerProvider) Create(name, value string, tags map[string]string, kms string) error {
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(value),
+	}
+
+	if kms != "" {
+		input.KmsKeyId = aws.String(kms)
+	}
+
+	for k, v := range tags {
+		input.Tags = append(input.Tags, &secretsmanager.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	_, err := p.svc.CreateSecret(input)
+	return err
+}
+
+func (p *secretsManagerProvider) Update(name, value string, kms string) error {
+	input := &secretsmanager.UpdateSecretInput{
+		SecretId:     aws.String(name),
+		SecretString: aws.String(value),
+	}
+
+	if kms != "" {
+		input.KmsKeyId = aws.String(kms)
+	}
+
+	_, err := p.svc.UpdateSecret(input)
+	return err
+}
+
+func (p *secretsManagerProvider) Delete(name string) error {
+	_, err := p.svc.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(name),
+	})
+	return err
+}
+
+// ListManaged implements providers.Pruner by paging through ListSecrets
+// with a tag filter.
+func (p *secretsManagerProvider) ListManaged(tagKey, tagValue string) ([]string, error) {
+	var names []string
+
+	input := &secretsmanager.ListSecretsInput{
+		Filters: []*secretsmanager.Filter{
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeTagKey),
+				Values: []*string{aws.String(tagKey)},
+			},
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeTagValue),
+				Values: []*string{aws.String(tagValue)},
+			},
+		},
+	}
+
+	err := p.svc.ListSecretsPages(input, func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, s := range page.SecretList {
+			if s.Name != nil {
+				names = append(names, *s.Name)
+			}
+		}
+		return true
+	})
+
+	return names, err
+}
+
+// DeleteWithRecovery implements providers.Pruner, honoring Secrets
+// Manager's recovery window before permanent deletion.
+func (p *secretsManagerProvider) DeleteWithRecovery(name string, recoveryWindowDays int64) error {
+	input := &secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(name),
+	}
+
+	if recoveryWindowDays == 0 {
+		input.ForceDeleteWithoutRecovery = aws.Bool(true)
+	} else {
+		input.RecoveryWindowInDays = aws.Int64(recoveryWindowDays)
+	}
+
+	_, err := p.svc.DeleteSecret(input)
+	return err
+}
+
+func isAWSError(err error, code string) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == code
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// ConfigureRotation implements providers.Rotator using Secrets Manager's
+// native RotateSecret call. It's safe to call on every apply: Secrets
+// Manager treats RotateSecret with an unchanged rotation rule as a no-op
+// beyond updating the rule.
+func (p *secretsManagerProvider) ConfigureRotation(name string, policy RotationPolicy, versionStages []string) error {
+	if policy.LambdaARN == "" {
+		return fmt.Errorf("rotation policy for %s is missing a lambda_arn", name)
+	}
+
+	input := &secretsmanager.RotateSecretInput{
+		SecretId:          aws.String(name),
+		RotationLambdaARN: aws.String(policy.LambdaARN),
+		RotationRules:     &secretsmanager.RotationRulesType{},
+	}
+
+	if policy.AutomaticallyAfterDays != 0 {
+		input.RotationRules.AutomaticallyAfterDays = aws.Int64(policy.AutomaticallyAfterDays)
+	}
+	if policy.Duration != "" {
+		input.RotationRules.Duration = aws.String(policy.Duration)
+	}
+	if policy.ScheduleExpression != "" {
+		input.RotationRules.ScheduleExpression = aws.String(policy.ScheduleExpression)
+	}
+
+	if _, err := p.svc.RotateSecret(input); err != nil {
+		return fmt.Errorf("rotating secret %s: %w", name, err)
+	}
+
+	if len(versionStages) == 0 {
+		return nil
+	}
+
+	current, err := p.svc.GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return fmt.Errorf("reading current version of %s to stage: %w", name, err)
+	}
+
+	_, err = p.svc.UpdateSecretVersionStage(&secretsmanager.UpdateSecretVersionStageInput{
+		SecretId:        aws.String(name),
+		VersionStage:    aws.String(versionStages[0]),
+		MoveToVersionId: current.VersionId,
+	})
+	if err != nil {
+		return fmt.Errorf("staging secret %s as %s: %w", name, versionStages[0], err)
+	}
+
+	return nil
+}
+
+// GetVersion implements providers.VersionedGetter, letting callers pin a
+// specific staging label (AWSCURRENT, AWSPREVIOUS, or a custom stage) when
+// comparing current vs desired instead of always reading the latest value.
+func (p *secretsManagerProvider) GetVersion(name, stage string) (string, error) {
+	out, err := p.svc.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId:     aws.String(name),
+		VersionStage: aws.String(stage),
+	})
+	if err != nil {
+		if isAWSError(err, secretsmanager.ErrCodeResourceNotFoundException) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	if out.SecretString == nil {
+		return "", ErrNotFound
+	}
+
+	return *out.SecretString, nil
+}
+
+// ReconcileReplication implements providers.ReplicationReconciler: it reads
+// the secret's current replica regions and reconciles them against the
+// desired list, adding missing regions and removing ones no longer wanted.
+func (p *secretsManagerProvider) ReconcileReplication(name string, regions []string) error {
+	desired := map[string]bool{}
+	for _, r := range regions {
+		desired[r] = true
+	}
+
+	describeOut, err := p.svc.DescribeSecret(&secretsmanager.DescribeSecretInput{SecretId: aws.String(name)})
+	if err != nil {
+		return fmt.Errorf("describing secret %s for replication: %w", name, err)
+	}
+
+	current := map[string]bool{}
+	for _, rep := range describeOut.ReplicationStatus {
+		if rep.Region != nil {
+			current[*rep.Region] = true
+		}
+	}
+
+	var toAdd []*secretsmanager.ReplicaRegionType
+	for region := range desired {
+		if !current[region] {
+			toAdd = append(toAdd, &secretsmanager.ReplicaRegionType{Region: aws.String(region)})
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if _, err := p.svc.ReplicateSecretToRegions(&secretsmanager.ReplicateSecretToRegionsInput{
+			SecretId:          aws.String(name),
+			AddReplicaRegions: toAdd,
+		}); err != nil {
+			return fmt.Errorf("adding replica regions for %s: %w", name, err)
+		}
+	}
+
+	var toRemove []*string
+	for region := range current {
+		if !desired[region] {
+			toRemove = append(toRemove, aws.String(region))
+		}
+	}
+
+	for _, region := range toRemove {
+		if _, err := p.svc.RemoveRegionsFromReplication(&secretsmanager.RemoveRegionsFromReplicationInput{
+			SecretId:             aws.String(name),
+			RemoveReplicaRegions: []*string{region},
+		}); err != nil {
+			return fmt.Errorf("removing replica region %s for %s: %w", *region, name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func init() {
+	Register("aws-ssm", newSSMProvider)
+}
+
+// ssmProvider stores secrets as SecureString parameters in AWS Systems
+// Manager Parameter Store, for users who don't want Secrets Manager's
+// per-secret pricing.
+type ssmProvider struct {
+	svc *ssm.SSM
+}
+
+func newSSMProvider(cfg Config) (Provider, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Profile: cfg.Profile,
+		Config: aws.Config{
+			Region: aws.String(cfg.Region),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssmProvider{svc: ssm.New(sess)}, nil
+}
+
+func (p *ssmProvider) Get(name string) (string, error) {
+	out, err := p.svc.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		if isAWSError(err, ssm.ErrCodeParameterNotFound) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", ErrNotFound
+	}
+
+	return *out.Parameter.Value, nil
+}
+
+func (p *ssmProvider) Create(name, value string, tags map[string]string, kms string) error {
+	return p.put(name, value, kms, false, tags)
+}
+
+func (p *ssmProvider) Update(name, value string, kms string) error {
+	return p.put(name, value, kms, true, nil)
+}
+
+func (p *ssmProvider) put(name, value, kms string, overwrite bool, tags map[string]string) error {
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(value),
+		Type:      aws.String(ssm.ParameterTypeSecureString),
+		Overwrite: aws.Bool(overwrite),
+	}
+
+	if kms != "" {
+		input.KeyId = aws.String(kms)
+	}
+
+	// SSM rejects Tags alongside Overwrite: true, so these only ever apply
+	// on the Create path.
+	for k, v := range tags {
+		input.Tags = append(input.Tags, &ssm.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	_, err := p.svc.PutParameter(input)
+	return err
+}
+
+func (p *ssmProvider) Delete(name string) error {
+	_, err := p.svc.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(name),
+	})
+	return err
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func init() {
+	Register("azure-kv", newAzureKeyVaultProvider)
+}
+
+// azureKeyVaultProvider stores secrets in an Azure Key Vault named by
+// cfg.Region (e.g. "https://my-vault.vault.azure.net/").
+type azureKeyVaultProvider struct {
+	client *azsecrets.Client
+}
+
+func newAzureKeyVaultProvider(cfg Config) (Provider, error) {
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("azure-kv provider requires a vault URL")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure credential: %w", err)
+	}
+
+	client, err := azsecrets.NewClient(cfg.Region, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating key vault client: %w", err)
+	}
+
+	return &azureKeyVaultProvider{client: client}, nil
+}
+
+func (p *azureKeyVaultProvider) Get(name string) (string, error) {
+	resp, err := p.client.GetSecret(context.Background(), name, "", nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	if resp.Value == nil {
+		return "", ErrNotFound
+	}
+
+	return *resp.Value, nil
+}
+
+func (p *azureKeyVaultProvider) Create(name, value string, tags map[string]string, kms string) error {
+	params := azsecrets.SetSecretParameters{Value: &value}
+
+	if len(tags) > 0 {
+		params.Tags = make(map[string]*string, len(tags))
+		for k, v := range tags {
+			v := v
+			params.Tags[k] = &v
+		}
+	}
+
+	_, err := p.client.SetSecret(context.Background(), name, params, nil)
+	return err
+}
+
+func (p *azureKeyVaultProvider) Update(name, value string, kms string) error {
+	_, err := p.client.SetSecret(context.Background(), name, azsecrets.SetSecretParameters{Value: &value}, nil)
+	return err
+}
+
+func (p *azureKeyVaultProvider) Delete(name string) error {
+	_, err := p.client.DeleteSecret(context.Background(), name, nil)
+	return err
+}
+
+func isAzureNotFound(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "SecretNotFound") || strings.Contains(err.Error(), "404"))
+}
@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func isGRPCNotFound(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.NotFound
+}
+
+func init() {
+	Register("gcp-sm", newGCPSecretManagerProvider)
+}
+
+// gcpSecretManagerProvider stores secrets in the GCP project named by
+// cfg.Region.
+type gcpSecretManagerProvider struct {
+	client  *secretmanager.Client
+	project string
+}
+
+func newGCPSecretManagerProvider(cfg Config) (Provider, error) {
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("gcp-sm provider requires a project id")
+	}
+
+	client, err := secretmanager.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("creating gcp secretmanager client: %w", err)
+	}
+
+	return &gcpSecretManagerProvider{client: client, project: cfg.Region}, nil
+}
+
+func (p *gcpSecretManagerProvider) secretName(name string) string {
+	return fmt.Sprintf("projects/%s/secrets/%s", p.project, name)
+}
+
+func (p *gcpSecretManagerProvider) Get(name string) (string, error) {
+	ctx := context.Background()
+
+	result, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: p.secretName(name) + "/versions/latest",
+	})
+	if err != nil {
+		if isGRPCNotFound(err) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	return string(result.Payload.Data), nil
+}
+
+func (p *gcpSecretManagerProvider) Create(name, value string, tags map[string]string, kms string) error {
+	ctx := context.Background()
+
+	req := &secretmanagerpb.CreateSecretRequest{
+		Parent:   fmt.Sprintf("projects/%s", p.project),
+		SecretId: name,
+		Secret: &secretmanagerpb.Secret{
+			Labels: tags,
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	}
+
+	if _, err := p.client.CreateSecret(ctx, req); err != nil {
+		return fmt.Errorf("creating secret %s: %w", name, err)
+	}
+
+	return p.addVersion(ctx, name, value)
+}
+
+func (p *gcpSecretManagerProvider) Update(name, value string, kms string) error {
+	return p.addVersion(context.Background(), name, value)
+}
+
+func (p *gcpSecretManagerProvider) addVersion(ctx context.Context, name, value string) error {
+	_, err := p.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: p.secretName(name),
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: []byte(value),
+		},
+	})
+	return err
+}
+
+func (p *gcpSecretManagerProvider) Delete(name string) error {
+	return p.client.DeleteSecret(context.Background(), &secretmanagerpb.DeleteSecretRequest{
+		Name: p.secretName(name),
+	})
+}
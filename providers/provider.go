@@ -0,0 +1,103 @@
+// Package providers defines the pluggable secret backend abstraction used by
+// mason. Each backend (AWS Secrets Manager, AWS SSM, Vault, GCP Secret
+// Manager, Azure Key Vault, ...) implements the Provider interface and
+// registers itself under a `type:` name so configs can mix backends in a
+// single run.
+package providers
+
+import "fmt"
+
+// ErrNotFound is returned by Get when the named secret does not exist in the
+// backend. Callers use this to distinguish "needs create" from a real
+// failure.
+var ErrNotFound = fmt.Errorf("secret not found")
+
+// Provider is a secret backend capable of reading and reconciling a single
+// secret by name.
+type Provider interface {
+	// Get returns the current value of the secret, or ErrNotFound if it
+	// does not exist yet.
+	Get(name string) (string, error)
+	// Create stores a new secret with the given value and tags.
+	Create(name, value string, tags map[string]string, kms string) error
+	// Update overwrites an existing secret's value.
+	Update(name, value string, kms string) error
+	// Delete removes the secret from the backend.
+	Delete(name string) error
+}
+
+// Pruner is implemented by backends that can list the secrets they manage,
+// so the `-prune` mode can find drift (secrets present in the backend but
+// no longer declared in config) without the caller needing backend-specific
+// code. Not every backend supports this; callers should type-assert.
+type Pruner interface {
+	// ListManaged returns the names of secrets tagged with the given
+	// key/value pair, e.g. managed-by=mason.
+	ListManaged(tagKey, tagValue string) ([]string, error)
+	// DeleteWithRecovery removes a secret, honoring a recovery/grace
+	// window (in days) where the backend supports one. A window of 0
+	// means delete immediately where possible.
+	DeleteWithRecovery(name string, recoveryWindowDays int64) error
+}
+
+// RotationPolicy mirrors a secret's `rotation:` YAML block: the Lambda that
+// knows how to rotate it and the schedule to invoke it on.
+type RotationPolicy struct {
+	LambdaARN              string
+	AutomaticallyAfterDays int64
+	Duration               string
+	ScheduleExpression     string
+}
+
+// Rotator is implemented by backends with a native rotation mechanism.
+// ConfigureRotation should be idempotent: calling it again with the same
+// policy and version stages is a no-op server-side.
+type Rotator interface {
+	ConfigureRotation(name string, policy RotationPolicy, versionStages []string) error
+}
+
+// VersionedGetter is implemented by backends that keep multiple labeled
+// versions of a secret (e.g. AWSCURRENT/AWSPREVIOUS), letting callers pin a
+// specific staging label instead of always comparing against the latest.
+type VersionedGetter interface {
+	GetVersion(name, stage string) (string, error)
+}
+
+// ReplicationReconciler is implemented by backends that can replicate a
+// secret to other regions. ReconcileReplication should add any region in
+// regions that isn't already a replica and remove any existing replica
+// region not in the list.
+type ReplicationReconciler interface {
+	ReconcileReplication(name string, regions []string) error
+}
+
+// Config carries the backend-specific settings needed to construct a
+// Provider. Type comes from a config's top-level or per-secret `type`
+// field; Region and Profile come from the -region and -profile flags.
+type Config struct {
+	Type    string
+	Region  string
+	Profile string
+}
+
+// Factory builds a Provider from a Config.
+type Factory func(cfg Config) (Provider, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a Factory under the given backend type name. Backend
+// packages call this from an init() so that importing them is enough to
+// make them available.
+func Register(kind string, f Factory) {
+	registry[kind] = f
+}
+
+// New constructs the Provider registered for kind, or an error if no backend
+// has registered under that name.
+func New(kind string, cfg Config) (Provider, error) {
+	f, ok := registry[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret provider type %q", kind)
+	}
+	return f(cfg)
+}
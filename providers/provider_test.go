@@ -0,0 +1,30 @@
+package providers
+
+import "testing"
+
+type fakeProvider struct{}
+
+func (fakeProvider) Get(name string) (string, error)                                     { return "", nil }
+func (fakeProvider) Create(name, value string, tags map[string]string, kms string) error { return nil }
+func (fakeProvider) Update(name, value string, kms string) error                         { return nil }
+func (fakeProvider) Delete(name string) error                                            { return nil }
+
+func TestRegisterAndNew(t *testing.T) {
+	Register("fake-test-provider", func(cfg Config) (Provider, error) {
+		return fakeProvider{}, nil
+	})
+
+	p, err := New("fake-test-provider", Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, ok := p.(fakeProvider); !ok {
+		t.Errorf("New() returned %T, want fakeProvider", p)
+	}
+}
+
+func TestNewUnknownKind(t *testing.T) {
+	if _, err := New("does-not-exist", Config{}); err == nil {
+		t.Error("New() error = nil, want non-nil for an unregistered kind")
+	}
+}
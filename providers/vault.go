@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+func init() {
+	Register("vault", newVaultProvider)
+}
+
+// vaultField is the key under which mason stores a secret's value inside
+// the Vault KV entry, keeping the entry shape predictable regardless of
+// what other tools write alongside it.
+const vaultField = "value"
+
+// vaultProvider talks to a HashiCorp Vault KV v2 mount, with the mount
+// prefix (e.g. "secret") passed in via cfg.Region.
+type vaultProvider struct {
+	client *vaultapi.Client
+	mount  string
+}
+
+func newVaultProvider(cfg Config) (Provider, error) {
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("creating vault client: %w", err)
+	}
+
+	mount := cfg.Region
+	if mount == "" {
+		mount = "secret"
+	}
+
+	return &vaultProvider{client: client, mount: mount}, nil
+}
+
+func (p *vaultProvider) Get(name string) (string, error) {
+	secret, err := p.client.Logical().Read(fmt.Sprintf("%s/data/%s", p.mount, name))
+	if err != nil {
+		return "", err
+	}
+	if secret == nil || secret.Data == nil {
+		return "", ErrNotFound
+	}
+
+	data, _ := secret.Data["data"].(map[string]interface{})
+	value, ok := data[vaultField].(string)
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (p *vaultProvider) Create(name, value string, tags map[string]string, kms string) error {
+	return p.write(name, value)
+}
+
+func (p *vaultProvider) Update(name, value string, kms string) error {
+	return p.write(name, value)
+}
+
+func (p *vaultProvider) write(name, value string) error {
+	_, err := p.client.Logical().Write(fmt.Sprintf("%s/data/%s", p.mount, name), map[string]interface{}{
+		"data": map[string]interface{}{
+			vaultField: value,
+		},
+	})
+	return err
+}
+
+func (p *vaultProvider) Delete(name string) error {
+	_, err := p.client.Logical().Delete(fmt.Sprintf("%s/metadata/%s", p.mount, name))
+	return err
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.mozilla.org/sops/v3/decrypt"
+	"gopkg.in/yaml.v2"
+)
+
+// encryptedValuePrefix marks an inline scalar as sops-encrypted, e.g.
+// `plaintext: sops:enc[<base64>]`. The base64 payload is a full
+// sops-encrypted JSON document (produced by running
+// `sops --encrypt --input-type json` over a single `{"value": "..."}`
+// document and base64-encoding the result), since sops has no format for
+// encrypting a bare scalar without its metadata block. This lets most of a
+// config stay plaintext for readable diffs while individual sensitive
+// values are ciphertext.
+const encryptedValuePrefix = "sops:enc["
+
+// isEncryptedConfigFile reports whether a config should be decrypted as a
+// whole file before being parsed: either it carries sops metadata, or its
+// name ends in .enc.yaml/.enc.yml.
+func isEncryptedConfigFile(path string, data []byte) bool {
+	if strings.HasSuffix(path, ".enc.yaml") || strings.HasSuffix(path, ".enc.yml") {
+		return true
+	}
+
+	var probe struct {
+		Sops map[string]interface{} `yaml:"sops"`
+	}
+
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+
+	return probe.Sops != nil
+}
+
+// decryptConfig decrypts a sops-encrypted YAML config, honoring whichever
+// of age (SOPS_AGE_KEY_FILE), KMS, or PGP recipients the file was encrypted
+// with.
+func decryptConfig(data []byte) ([]byte, error) {
+	plain, err := decrypt.Data(data, "yaml")
+	if err != nil {
+		return nil, fmt.Errorf("decrypting sops config: %w", err)
+	}
+	return plain, nil
+}
+
+// isEncryptedScalar reports whether a YAML scalar is an inline sops value
+// rather than plaintext.
+func isEncryptedScalar(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix) && strings.HasSuffix(value, "]")
+}
+
+// decryptScalar decrypts a single `sops:enc[...]` scalar. The wrapper holds
+// a base64-encoded, fully sops-encrypted JSON document wrapping the value
+// (see encryptedValuePrefix), so we decode it, decrypt it the same way a
+// whole config file is decrypted, and pull the value back out.
+func decryptScalar(value string) (string, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(value, encryptedValuePrefix), "]")
+
+	encoded, err := base64.StdEncoding.DecodeString(inner)
+	if err != nil {
+		return "", fmt.Errorf("decoding inline secret: %w", err)
+	}
+
+	plain, err := decrypt.Data(encoded, "json")
+	if err != nil {
+		return "", fmt.Errorf("decrypting inline secret: %w", err)
+	}
+
+	var doc struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(plain, &doc); err != nil {
+		return "", fmt.Errorf("parsing decrypted inline secret: %w", err)
+	}
+
+	return doc.Value, nil
+}
+
+// decryptInlineValues walks every plaintext/key_value scalar in the config
+// and decrypts any that carry the sops:enc[...] wrapper.
+func decryptInlineValues(config *Config) error {
+	for name, secret := range config.Secrets {
+		if isEncryptedScalar(secret.PlainText) {
+			plain, err := decryptScalar(secret.PlainText)
+			if err != nil {
+				return fmt.Errorf("secret '%s': %w", name, err)
+			}
+			secret.PlainText = plain
+		}
+
+		for k, v := range secret.KeyValue {
+			if isEncryptedScalar(v) {
+				plain, err := decryptScalar(v)
+				if err != nil {
+					return fmt.Errorf("secret '%s' key '%s': %w", name, k, err)
+				}
+				secret.KeyValue[k] = plain
+			}
+		}
+
+		config.Secrets[name] = secret
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	Register("exec", newExecSource)
+}
+
+// ExecConfig configures an `exec` source, which runs a command and captures
+// its stdout as the secret value. Modeled on Reckoner's ShellExecutor.
+type ExecConfig struct {
+	Command []string      `yaml:"command"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+type execSource struct {
+	cfg ExecConfig
+}
+
+func newExecSource(raw map[string]interface{}) (Source, error) {
+	var cfg ExecConfig
+	if err := decodeSourceConfig(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Command) == 0 {
+		return nil, fmt.Errorf("exec source requires a non-empty command")
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &execSource{cfg: cfg}, nil
+}
+
+func (s *execSource) Resolve() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.cfg.Command[0], s.cfg.Command[1:]...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running %q: %w (stderr: %s)", strings.Join(s.cfg.Command, " "), err, stderr.String())
+	}
+
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// decodeSourceConfig re-marshals the generic YAML map mason already parsed
+// into the kind-specific config struct, since gopkg.in/yaml.v2 has no
+// direct "decode this node later" hook.
+func decodeSourceConfig(raw map[string]interface{}, out interface{}) error {
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("re-encoding source config: %w", err)
+	}
+	return yaml.Unmarshal(data, out)
+}
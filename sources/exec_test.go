@@ -0,0 +1,39 @@
+package sources
+
+import "testing"
+
+func TestExecSourceResolve(t *testing.T) {
+	src, err := newExecSource(map[string]interface{}{
+		"command": []interface{}{"echo", "hello"},
+	})
+	if err != nil {
+		t.Fatalf("newExecSource() error = %v", err)
+	}
+
+	got, err := src.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != "hello" {
+		t.Errorf("Resolve() = %q, want %q", got, "hello")
+	}
+}
+
+func TestExecSourceResolveCommandFailure(t *testing.T) {
+	src, err := newExecSource(map[string]interface{}{
+		"command": []interface{}{"sh", "-c", "exit 1"},
+	})
+	if err != nil {
+		t.Fatalf("newExecSource() error = %v", err)
+	}
+
+	if _, err := src.Resolve(); err == nil {
+		t.Error("Resolve() error = nil, want non-nil for a failing command")
+	}
+}
+
+func TestNewExecSourceRequiresCommand(t *testing.T) {
+	if _, err := newExecSource(map[string]interface{}{}); err == nil {
+		t.Error("newExecSource() error = nil, want non-nil for an empty command")
+	}
+}
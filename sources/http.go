@@ -0,0 +1,97 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PaesslerAG/jsonpath"
+)
+
+func init() {
+	Register("http", newHTTPSource)
+}
+
+// HTTPConfig configures an `http` source, which fetches the secret value
+// from an HTTP endpoint, optionally extracting a field from a JSON
+// response body via JSONPath.
+type HTTPConfig struct {
+	Method   string            `yaml:"method,omitempty"`
+	URL      string            `yaml:"url"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+	Body     string            `yaml:"body,omitempty"`
+	JSONPath string            `yaml:"jsonpath,omitempty"`
+	Timeout  time.Duration     `yaml:"timeout,omitempty"`
+}
+
+type httpSource struct {
+	cfg HTTPConfig
+}
+
+func newHTTPSource(raw map[string]interface{}) (Source, error) {
+	var cfg HTTPConfig
+	if err := decodeSourceConfig(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("http source requires a url")
+	}
+
+	if cfg.Method == "" {
+		cfg.Method = http.MethodGet
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &httpSource{cfg: cfg}, nil
+}
+
+func (s *httpSource) Resolve() (string, error) {
+	client := &http.Client{Timeout: s.cfg.Timeout}
+
+	req, err := http.NewRequest(s.cfg.Method, s.cfg.URL, strings.NewReader(s.cfg.Body))
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", s.cfg.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading response from %s: %w", s.cfg.URL, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("fetching %s: unexpected status %d", s.cfg.URL, resp.StatusCode)
+	}
+
+	if s.cfg.JSONPath == "" {
+		return string(body), nil
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("parsing JSON response from %s: %w", s.cfg.URL, err)
+	}
+
+	value, err := jsonpath.Get(s.cfg.JSONPath, parsed)
+	if err != nil {
+		return "", fmt.Errorf("evaluating jsonpath %q: %w", s.cfg.JSONPath, err)
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
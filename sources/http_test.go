@@ -0,0 +1,85 @@
+package sources
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSourceResolveGET(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("method = %s, want GET", r.Method)
+		}
+		w.Write([]byte("plain-value"))
+	}))
+	defer srv.Close()
+
+	src, err := newHTTPSource(map[string]interface{}{"url": srv.URL})
+	if err != nil {
+		t.Fatalf("newHTTPSource() error = %v", err)
+	}
+
+	got, err := src.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != "plain-value" {
+		t.Errorf("Resolve() = %q, want %q", got, "plain-value")
+	}
+}
+
+func TestHTTPSourceResolvePOSTWithJSONPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"grant_type":"client_credentials"}` {
+			t.Errorf("body = %s", body)
+		}
+		w.Write([]byte(`{"token":"minted-secret"}`))
+	}))
+	defer srv.Close()
+
+	src, err := newHTTPSource(map[string]interface{}{
+		"method":   "POST",
+		"url":      srv.URL,
+		"body":     `{"grant_type":"client_credentials"}`,
+		"jsonpath": "$.token",
+	})
+	if err != nil {
+		t.Fatalf("newHTTPSource() error = %v", err)
+	}
+
+	got, err := src.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != "minted-secret" {
+		t.Errorf("Resolve() = %q, want %q", got, "minted-secret")
+	}
+}
+
+func TestHTTPSourceResolveNonSuccessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	src, err := newHTTPSource(map[string]interface{}{"url": srv.URL})
+	if err != nil {
+		t.Fatalf("newHTTPSource() error = %v", err)
+	}
+
+	if _, err := src.Resolve(); err == nil {
+		t.Error("Resolve() error = nil, want non-nil for a 500 response")
+	}
+}
+
+func TestNewHTTPSourceRequiresURL(t *testing.T) {
+	if _, err := newHTTPSource(map[string]interface{}{}); err == nil {
+		t.Error("newHTTPSource() error = nil, want non-nil when url is missing")
+	}
+}
@@ -0,0 +1,39 @@
+// Package sources implements dynamic secret value producers for mason. A
+// secret's `source:` block names a kind ("exec", "template", "http") and
+// kind-specific settings; at sync time mason resolves the value through the
+// matching Source instead of reading a static plaintext/file/key_value.
+package sources
+
+import "fmt"
+
+// Source produces a secret's value at sync time.
+type Source interface {
+	// Resolve returns the value to store for the secret.
+	Resolve() (string, error)
+}
+
+// RefsSetter is implemented by sources that can use other secrets' already-
+// resolved values (e.g. template). Callers that have such values available
+// should type-assert and call SetRefs before Resolve.
+type RefsSetter interface {
+	SetRefs(refs map[string]string)
+}
+
+// Factory builds a Source from its parsed YAML config.
+type Factory func(cfg map[string]interface{}) (Source, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a Factory under the given source kind name.
+func Register(kind string, f Factory) {
+	registry[kind] = f
+}
+
+// New constructs the Source registered for kind.
+func New(kind string, cfg map[string]interface{}) (Source, error) {
+	f, ok := registry[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret source kind %q", kind)
+	}
+	return f(cfg)
+}
@@ -0,0 +1,86 @@
+package sources
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/template"
+)
+
+func init() {
+	Register("template", newTemplateSource)
+}
+
+// TemplateConfig configures a `template` source, which renders a Go
+// text/template file with the process environment and other resolved
+// secrets available as template data.
+type TemplateConfig struct {
+	File string `yaml:"file"`
+}
+
+type templateSource struct {
+	cfg TemplateConfig
+	// Refs holds other secrets' resolved values, keyed by name, so a
+	// template can reference `{{ .Secrets.db_password }}`.
+	Refs map[string]string
+}
+
+func newTemplateSource(raw map[string]interface{}) (Source, error) {
+	var cfg TemplateConfig
+	if err := decodeSourceConfig(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.File == "" {
+		return nil, fmt.Errorf("template source requires a file")
+	}
+
+	return &templateSource{cfg: cfg}, nil
+}
+
+// SetRefs implements sources.RefsSetter. The caller passes in the other
+// secrets it has already resolved so the template can reference them.
+func (s *templateSource) SetRefs(refs map[string]string) {
+	s.Refs = refs
+}
+
+func (s *templateSource) Resolve() (string, error) {
+	contents, err := ioutil.ReadFile(s.cfg.File)
+	if err != nil {
+		return "", fmt.Errorf("reading template %s: %w", s.cfg.File, err)
+	}
+
+	tmpl, err := template.New(s.cfg.File).Parse(string(contents))
+	if err != nil {
+		return "", fmt.Errorf("parsing template %s: %w", s.cfg.File, err)
+	}
+
+	data := struct {
+		Env     map[string]string
+		Secrets map[string]string
+	}{
+		Env:     envMap(),
+		Secrets: s.Refs,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering template %s: %w", s.cfg.File, err)
+	}
+
+	return buf.String(), nil
+}
+
+func envMap() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		for i := 0; i < len(kv); i++ {
+			if kv[i] == '=' {
+				env[kv[:i]] = kv[i+1:]
+				break
+			}
+		}
+	}
+	return env
+}